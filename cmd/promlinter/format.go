@@ -0,0 +1,110 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/simonpasquier/promlinter/pkg/linter"
+)
+
+func formatText(w io.Writer, findings []linter.Finding) error {
+	for _, f := range findings {
+		if f.RuleID == "" {
+			fmt.Fprintf(w, "%s: %s: %s\n", f.Severity, formatPosition(f.Position), f.Message)
+		} else if f.Metric != "" {
+			fmt.Fprintf(w, "%s: rule %q: metric %q: %s\n", f.Severity, f.RuleID, f.Metric, f.Message)
+		} else {
+			fmt.Fprintf(w, "%s: rule %q: %s\n", f.Severity, f.RuleID, f.Message)
+		}
+	}
+	return nil
+}
+
+// formatPosition renders a Position as "file:line", omitting the line
+// when it is unset.
+func formatPosition(p linter.Position) string {
+	if p.Line == 0 {
+		return p.File
+	}
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+func formatJSON(w io.Writer, findings []linter.Finding) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(findings)
+}
+
+type junitTestsuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Testcases []junitTestcase `xml:"testcase"`
+}
+
+type junitTestcase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func formatJUnit(w io.Writer, findings []linter.Finding) error {
+	suite := junitTestsuite{Name: "promlinter"}
+	for _, f := range findings {
+		name := f.RuleID
+		if name == "" {
+			name = formatPosition(f.Position)
+		}
+		tc := junitTestcase{Name: name, Classname: f.Kind}
+		if f.Severity == linter.SeverityError {
+			suite.Failures++
+			tc.Failure = &junitFailure{Message: f.Message, Text: f.Message}
+		}
+		suite.Tests++
+		suite.Testcases = append(suite.Testcases, tc)
+	}
+
+	fmt.Fprint(w, xml.Header)
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return errors.Wrap(err, "failed to encode JUnit report")
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+func formatFindings(w io.Writer, format string, findings []linter.Finding) error {
+	switch format {
+	case "", "text":
+		return formatText(w, findings)
+	case "json":
+		return formatJSON(w, findings)
+	case "junit":
+		return formatJUnit(w, findings)
+	default:
+		return errors.Errorf("unknown -output format %q", format)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command promlinter lints Prometheus recording and alerting rules, either
+// from a live Prometheus or from rule files on disk.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	"github.com/simonpasquier/promlinter/pkg/linter"
+)
+
+var (
+	help      bool
+	promURL   string
+	ruleFiles string
+	output    string
+
+	failOnWarnings bool
+	evalRange      string
+	evalStep       string
+	maxSeries      int
+
+	basicAuthUser         string
+	basicAuthPass         string
+	basicAuthPassFile     string
+	bearerToken           string
+	bearerTokenFile       string
+	headers               headerFlags
+	tlsCAFile             string
+	tlsCertFile           string
+	tlsKeyFile            string
+	tlsInsecureSkipVerify bool
+)
+
+func init() {
+	flag.BoolVar(&help, "help", false, "Help message")
+	flag.StringVar(&promURL, "url", "", "Prometheus base URL")
+	flag.StringVar(&ruleFiles, "rule-files", "", "Glob matching rule files to lint instead of querying -url for the live rules")
+	flag.StringVar(&output, "output", "text", "Output format: text, json or junit")
+
+	flag.BoolVar(&failOnWarnings, "fail-on-warnings", false, "Exit with an error if the Prometheus API returns warnings (e.g. partial responses from Thanos/Cortex)")
+	flag.StringVar(&evalRange, "eval-range", "", "Evaluate each rule's query over this historical duration (e.g. 24h) and flag empty or high-cardinality results")
+	flag.StringVar(&evalStep, "eval-step", "5m", "Step to use with -eval-range")
+	flag.IntVar(&maxSeries, "max-series", 0, "With -eval-range, flag rules whose result has more than this many series at any step (0 disables the check)")
+
+	flag.StringVar(&basicAuthUser, "basic-auth-user", "", "Username for HTTP basic authentication")
+	flag.StringVar(&basicAuthPass, "basic-auth-pass", "", "Password for HTTP basic authentication")
+	flag.StringVar(&basicAuthPassFile, "basic-auth-password-file", "", "File containing the password for HTTP basic authentication")
+	flag.StringVar(&bearerToken, "bearer-token", "", "Bearer token for authentication")
+	flag.StringVar(&bearerTokenFile, "bearer-token-file", "", "File containing the bearer token for authentication")
+	flag.Var(&headers, "header", "Additional HTTP header to send with every request, as key=value (may be repeated)")
+	flag.StringVar(&tlsCAFile, "tls-ca-file", "", "CA certificate to verify the Prometheus server against")
+	flag.StringVar(&tlsCertFile, "tls-cert-file", "", "Client certificate for mutual TLS")
+	flag.StringVar(&tlsKeyFile, "tls-key-file", "", "Client key for mutual TLS")
+	flag.BoolVar(&tlsInsecureSkipVerify, "tls-insecure-skip-verify", false, "Disable TLS certificate verification")
+}
+
+// headerFlags collects repeated -header key=value flags.
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ",")
+}
+
+func (h *headerFlags) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return errors.Errorf("invalid -header %q: expected key=value", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	if help {
+		fmt.Fprintln(os.Stderr, "Prometheus rules linter")
+		flag.PrintDefaults()
+		os.Exit(0)
+	}
+
+	l, source, err := run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	findings, err := l.Lint(source)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := formatFindings(os.Stdout, output, findings); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	os.Exit(l.ExitCode(findings))
+}
+
+// run parses flags into a Linter and the RuleSource it should lint,
+// without actually running the lint (so main can use the Linter's
+// ExitCode afterwards).
+func run() (*linter.Linter, linter.RuleSource, error) {
+	if ruleFiles != "" {
+		l := linter.New(linter.Config{FailOnWarnings: failOnWarnings})
+		return l, linter.NewFileRuleSource(ruleFiles), nil
+	}
+
+	if promURL == "" {
+		return nil, nil, errors.New("Missing -url parameter")
+	}
+	u, err := url.Parse(promURL)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil, errors.Errorf("Invalid URL scheme: %s", u.Scheme)
+	}
+
+	client, err := newClient(promURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := linter.Config{
+		Client:         client,
+		FailOnWarnings: failOnWarnings,
+		MaxSeries:      maxSeries,
+	}
+	if evalRange != "" {
+		cfg.EvalRange, err = time.ParseDuration(evalRange)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid -eval-range")
+		}
+		cfg.EvalStep, err = time.ParseDuration(evalStep)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid -eval-step")
+		}
+		if cfg.EvalStep <= 0 {
+			return nil, nil, errors.Errorf("invalid -eval-step %q: must be positive", evalStep)
+		}
+	}
+
+	return linter.New(cfg), linter.NewAPIRuleSource(client), nil
+}
+
+func newClient(promURL string) (api.Client, error) {
+	hdr := make(map[string][]string)
+	for _, h := range headers {
+		parts := strings.SplitN(h, "=", 2)
+		hdr[parts[0]] = append(hdr[parts[0]], parts[1])
+	}
+
+	rt, err := linter.NewRoundTripper(linter.TransportConfig{
+		BasicAuthUser:         basicAuthUser,
+		BasicAuthPass:         basicAuthPass,
+		BasicAuthPassFile:     basicAuthPassFile,
+		BearerToken:           bearerToken,
+		BearerTokenFile:       bearerTokenFile,
+		Headers:               hdr,
+		TLSCAFile:             tlsCAFile,
+		TLSCertFile:           tlsCertFile,
+		TLSKeyFile:            tlsKeyFile,
+		TLSInsecureSkipVerify: tlsInsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewClient(api.Config{Address: promURL, RoundTripper: rt})
+}
@@ -0,0 +1,37 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+// Check inspects a single Rule and reports any problems it finds. New
+// checks register themselves from an init() function with RegisterCheck,
+// the same pattern node_exporter and postgres_exporter use for collectors.
+type Check interface {
+	Name() string
+	Check(l *Linter, rule Rule) []Finding
+}
+
+type registeredCheck struct {
+	name    string
+	factory func() Check
+}
+
+// registry preserves registration order (which follows Go's deterministic,
+// alphabetical-by-filename init() order within a package) so that output
+// doesn't depend on map iteration order.
+var registry []registeredCheck
+
+// RegisterCheck makes a Check available to every Linter created with New.
+func RegisterCheck(name string, factory func() Check) {
+	registry = append(registry, registeredCheck{name: name, factory: factory})
+}
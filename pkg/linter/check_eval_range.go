@@ -0,0 +1,80 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+func init() {
+	RegisterCheck("eval-range", func() Check { return &evalRangeCheck{} })
+}
+
+// evalRangeCheck dry-runs a rule's query over a historical window and
+// flags results that are empty for the entire window or whose cardinality
+// explodes past MaxSeries.
+type evalRangeCheck struct{}
+
+func (c *evalRangeCheck) Name() string { return "eval-range" }
+
+func (c *evalRangeCheck) Check(l *Linter, rule Rule) []Finding {
+	if l.Client == nil || l.EvalRange == 0 {
+		return nil
+	}
+
+	end := time.Now()
+	rng := v1.Range{Start: end.Add(-l.EvalRange), End: end, Step: l.EvalStep}
+
+	stats, warnings, err := l.queryRange(rule.Query, rng)
+
+	var findings []Finding
+	for _, w := range warnings {
+		findings = append(findings, Finding{Severity: SeverityWarning, Kind: kindAPIWarning, RuleID: rule.ID(), Message: string(w)})
+	}
+	if err != nil {
+		findings = append(findings, Finding{Severity: SeverityError, Kind: c.Name(), RuleID: rule.ID(), Message: err.Error()})
+		return findings
+	}
+
+	if stats.Empty {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Kind:     c.Name(),
+			RuleID:   rule.ID(),
+			Message:  fmt.Sprintf("empty result over the last %s", l.EvalRange),
+		})
+		return findings
+	}
+
+	findings = append(findings, Finding{
+		Severity: SeverityNote,
+		Kind:     c.Name(),
+		RuleID:   rule.ID(),
+		Message:  fmt.Sprintf("series count min=%d max=%d avg=%.1f gaps=%d", stats.MinSeries, stats.MaxSeries, stats.AvgSeries, stats.Gaps),
+	})
+
+	if l.MaxSeries > 0 && stats.MaxSeries > l.MaxSeries {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Kind:     c.Name(),
+			RuleID:   rule.ID(),
+			Message:  fmt.Sprintf("series count %d exceeds -max-series=%d", stats.MaxSeries, l.MaxSeries),
+		})
+	}
+
+	return findings
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import "fmt"
+
+func init() {
+	RegisterCheck("label-matchers", func() Check { return &labelMatchersCheck{} })
+}
+
+// labelMatchersCheck flags selector matchers that can never match any
+// known value of their label, e.g. job="api-servr" when only api-server
+// exists.
+type labelMatchersCheck struct{}
+
+func (c *labelMatchersCheck) Name() string { return "label-matchers" }
+
+func (c *labelMatchersCheck) Check(l *Linter, rule Rule) []Finding {
+	if l.Client == nil {
+		return nil
+	}
+
+	selectors, err := l.getMetrics(rule.Query)
+	if err != nil {
+		// Already reported by the missing-metric check.
+		return nil
+	}
+
+	var findings []Finding
+	for _, sel := range selectors {
+		bad, err := l.labelMatchersValid(sel)
+		if err != nil {
+			findings = append(findings, Finding{Severity: SeverityError, Kind: c.Name(), RuleID: rule.ID(), Metric: sel.Name, Message: err.Error()})
+			continue
+		}
+		for _, m := range bad {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Kind:     c.Name(),
+				RuleID:   rule.ID(),
+				Metric:   sel.Name,
+				Message:  fmt.Sprintf("matcher %s can never match any known value of label %q", m, m.Name),
+			})
+		}
+	}
+
+	return findings
+}
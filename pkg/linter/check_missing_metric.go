@@ -0,0 +1,67 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import "fmt"
+
+func init() {
+	RegisterCheck("missing-metric", func() Check { return &missingMetricCheck{} })
+}
+
+// missingMetricCheck flags selectors whose metric name isn't known to the
+// queried Prometheus, unless it's produced by a recording rule in the same
+// rule set.
+type missingMetricCheck struct{}
+
+func (c *missingMetricCheck) Name() string { return "missing-metric" }
+
+func (c *missingMetricCheck) Check(l *Linter, rule Rule) []Finding {
+	if l.Client == nil {
+		// Offline mode: there's no Prometheus to check metrics against.
+		return nil
+	}
+
+	selectors, err := l.getMetrics(rule.Query)
+	if err != nil {
+		return []Finding{{Severity: SeverityError, Kind: c.Name(), RuleID: rule.ID(), Message: err.Error()}}
+	}
+
+	var findings []Finding
+	for _, sel := range selectors {
+		found, warnings, err := l.metricExists(sel.Name)
+		if err != nil {
+			findings = append(findings, Finding{Severity: SeverityError, Kind: c.Name(), RuleID: rule.ID(), Metric: sel.Name, Message: err.Error()})
+			continue
+		}
+		for _, w := range warnings {
+			findings = append(findings, Finding{Severity: SeverityWarning, Kind: kindAPIWarning, RuleID: rule.ID(), Metric: sel.Name, Message: string(w)})
+		}
+		if found {
+			continue
+		}
+		if l.recordingRules[sel.Name] {
+			findings = append(findings, Finding{
+				Severity: SeverityNote,
+				Kind:     c.Name(),
+				RuleID:   rule.ID(),
+				Metric:   sel.Name,
+				Message:  fmt.Sprintf("not found, but provided by recording rule %s", sel.Name),
+			})
+			continue
+		}
+		findings = append(findings, Finding{Severity: SeverityError, Kind: c.Name(), RuleID: rule.ID(), Metric: sel.Name, Message: "metric not found"})
+	}
+
+	return findings
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+// Severity ranks how serious a Finding is. CI systems use it to decide
+// whether a run should fail.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Position locates a Finding in a rule file, when that information is
+// available (it isn't for rules loaded from a live Prometheus).
+type Position struct {
+	File string
+	Line int
+}
+
+// Finding is a single problem reported by a Check.
+type Finding struct {
+	Severity Severity
+	// Kind is the name of the Check that produced the Finding, or
+	// "api-warning" for a warning relayed from the Prometheus API, or
+	// "rule-file-parse" for an error found while loading a rule file.
+	Kind     string
+	RuleID   string
+	Metric   string
+	Message  string
+	Position Position
+}
+
+// kindAPIWarning marks Findings relaying a warning string returned
+// verbatim by the Prometheus API (e.g. partial responses from
+// Thanos/Cortex), as opposed to one a Check computed itself.
+const kindAPIWarning = "api-warning"
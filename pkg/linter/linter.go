@@ -0,0 +1,107 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package linter validates Prometheus recording and alerting rules,
+// checking that the metrics and label values they select actually exist
+// and that their queries behave sanely over time.
+package linter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+)
+
+// Config holds everything a Linter needs beyond the rules themselves.
+type Config struct {
+	// Client talks to the Prometheus HTTP API. A nil Client puts the
+	// Linter in offline mode: only checks that don't need live data run.
+	Client api.Client
+
+	// EvalRange and EvalStep configure the eval-range check. EvalRange
+	// zero disables it.
+	EvalRange time.Duration
+	EvalStep  time.Duration
+	MaxSeries int
+
+	FailOnWarnings bool
+}
+
+// Linter runs the registered Checks against a set of rules.
+type Linter struct {
+	Config
+
+	metrics        map[string]bool
+	recordingRules map[string]bool
+	labelValues    map[string][]string
+	checks         []Check
+}
+
+// New builds a Linter with every registered Check enabled.
+func New(cfg Config) *Linter {
+	l := &Linter{
+		Config:      cfg,
+		metrics:     make(map[string]bool),
+		labelValues: make(map[string][]string),
+	}
+	for _, c := range registry {
+		l.checks = append(l.checks, c.factory())
+	}
+	return l
+}
+
+// Lint loads rules from source and runs every enabled Check against each
+// of them, returning the combined findings in rule order.
+func (l *Linter) Lint(source RuleSource) ([]Finding, error) {
+	rules, findings, err := source.GetRules()
+	if err != nil {
+		return nil, err
+	}
+
+	l.recordingRules = make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Kind == KindRecording {
+			l.recordingRules[rule.Record] = true
+		}
+	}
+
+	for _, rule := range rules {
+		for _, c := range l.checks {
+			findings = append(findings, c.Check(l, rule)...)
+		}
+	}
+
+	return findings, nil
+}
+
+// ExitCode maps the worst Finding severity to a CI-style exit code: 0
+// means nothing actionable was found. Config.FailOnWarnings only makes
+// api-warning Findings (Prometheus API warnings such as partial responses
+// from Thanos/Cortex) count against the exit code; warnings from other
+// Checks, like label-matchers or eval-range, never fail the run on their
+// own.
+func (l *Linter) ExitCode(findings []Finding) int {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return 1
+		}
+	}
+	if l.FailOnWarnings {
+		for _, f := range findings {
+			if f.Severity == SeverityWarning && f.Kind == kindAPIWarning {
+				return 1
+			}
+		}
+	}
+	return 0
+}
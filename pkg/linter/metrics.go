@@ -0,0 +1,201 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+)
+
+// metricSelector is a vector or matrix selector found in a rule expression,
+// carrying its full label matchers rather than just the metric name.
+type metricSelector struct {
+	Name     string
+	Matchers []*labels.Matcher
+}
+
+// getMetrics walks a PromQL query and returns every vector/matrix selector
+// it finds, including their label matchers.
+func (l *Linter) getMetrics(query string) ([]metricSelector, error) {
+	expr, err := promql.ParseExpr(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectors []metricSelector
+	promql.Inspect(expr, func(node promql.Node, _ []promql.Node) error {
+		switch n := node.(type) {
+		case *promql.VectorSelector:
+			if n.Name == "" {
+				return nil
+			}
+			selectors = append(selectors, metricSelector{Name: n.Name, Matchers: n.LabelMatchers})
+		case *promql.MatrixSelector:
+			if n.Name == "" {
+				return nil
+			}
+			selectors = append(selectors, metricSelector{Name: n.Name, Matchers: n.LabelMatchers})
+		default:
+		}
+		return nil
+	})
+
+	return selectors, nil
+}
+
+// metricExists reports whether name is known to the queried Prometheus. The
+// returned warnings come straight from the API call and are empty when the
+// result was served from the cache.
+func (l *Linter) metricExists(name string) (bool, v1.Warnings, error) {
+	_, ok := l.metrics[name]
+	if ok {
+		return l.metrics[name], nil, nil
+	}
+
+	lset, warnings, err := v1.NewAPI(l.Client).Series(context.Background(), []string{name}, time.Time{}, time.Now())
+	if err != nil {
+		return false, warnings, errors.Wrapf(err, "failed to get metric %q", name)
+	}
+	l.metrics[name] = len(lset) > 0
+	return l.metrics[name], warnings, nil
+}
+
+// labelValuesFor returns the known values for label, caching the result
+// across calls.
+func (l *Linter) labelValuesFor(label string) ([]string, error) {
+	if v, ok := l.labelValues[label]; ok {
+		return v, nil
+	}
+
+	values, _, err := v1.NewAPI(l.Client).LabelValues(context.Background(), label)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get values for label %q", label)
+	}
+
+	v := make([]string, 0, len(values))
+	for _, lv := range values {
+		v = append(v, string(lv))
+	}
+	l.labelValues[label] = v
+	return v, nil
+}
+
+// labelMatchersValid reports matchers on sel that can never match anything
+// given the known values of their label, e.g. job="api-servr" when only
+// api-server exists.
+func (l *Linter) labelMatchersValid(sel metricSelector) ([]*labels.Matcher, error) {
+	var bad []*labels.Matcher
+	for _, m := range sel.Matchers {
+		if m.Name == labels.MetricName {
+			continue
+		}
+		if m.Type != labels.MatchEqual && m.Type != labels.MatchRegexp {
+			continue
+		}
+		if m.Matches("") {
+			// foo="", foo=~"" and foo=~".*" select series that lack the
+			// label, which LabelValues never reports a value for.
+			continue
+		}
+
+		values, err := l.labelValuesFor(m.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			// The label isn't known at all; the missing-metric check
+			// already reports missing metrics, so don't pile on here.
+			continue
+		}
+
+		matches := false
+		for _, v := range values {
+			if m.Matches(v) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			bad = append(bad, m)
+		}
+	}
+	return bad, nil
+}
+
+// rangeStats summarizes how many series a range-query result produced
+// across the evaluated window.
+type rangeStats struct {
+	Empty     bool
+	MinSeries int
+	MaxSeries int
+	AvgSeries float64
+	Gaps      int
+}
+
+// queryRange evaluates query over rng via the range query API, used to dry
+// run a rule against historical data without requiring a TSDB block writer.
+func (l *Linter) queryRange(query string, rng v1.Range) (rangeStats, v1.Warnings, error) {
+	val, warnings, err := v1.NewAPI(l.Client).QueryRange(context.Background(), query, rng)
+	if err != nil {
+		return rangeStats{}, warnings, errors.Wrapf(err, "failed to evaluate query %q over range", query)
+	}
+
+	matrix, ok := val.(model.Matrix)
+	if !ok {
+		return rangeStats{}, warnings, errors.Errorf("unexpected result type %s for range query", val.Type())
+	}
+
+	return computeRangeStats(matrix, rng), warnings, nil
+}
+
+// computeRangeStats summarizes the per-step series counts of a range query
+// result. It's split out from queryRange so the math can be tested without
+// a live Prometheus.
+func computeRangeStats(matrix model.Matrix, rng v1.Range) rangeStats {
+	counts := make(map[model.Time]int)
+	for _, series := range matrix {
+		for _, sample := range series.Values {
+			counts[sample.Timestamp]++
+		}
+	}
+	if len(counts) == 0 {
+		return rangeStats{Empty: true}
+	}
+
+	stats := rangeStats{MinSeries: -1}
+	var total int
+	for _, c := range counts {
+		if stats.MinSeries == -1 || c < stats.MinSeries {
+			stats.MinSeries = c
+		}
+		if c > stats.MaxSeries {
+			stats.MaxSeries = c
+		}
+		total += c
+	}
+	stats.AvgSeries = float64(total) / float64(len(counts))
+
+	expectedSteps := int(rng.End.Sub(rng.Start)/rng.Step) + 1
+	if stats.Gaps = expectedSteps - len(counts); stats.Gaps < 0 {
+		stats.Gaps = 0
+	}
+
+	return stats
+}
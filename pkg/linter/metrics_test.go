@@ -0,0 +1,164 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+)
+
+func mustMatcher(t *testing.T, mt labels.MatchType, name, value string) *labels.Matcher {
+	t.Helper()
+	m, err := labels.NewMatcher(mt, name, value)
+	if err != nil {
+		t.Fatalf("NewMatcher(%q, %q): %v", name, value, err)
+	}
+	return m
+}
+
+func TestLabelMatchersValid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		matcher *labels.Matcher
+		values  []string
+		wantBad bool
+	}{
+		{
+			name:    "known value",
+			matcher: mustMatcher(t, labels.MatchEqual, "job", "api-server"),
+			values:  []string{"api-server", "db"},
+		},
+		{
+			name:    "unknown value",
+			matcher: mustMatcher(t, labels.MatchEqual, "job", "api-servr"),
+			values:  []string{"api-server", "db"},
+			wantBad: true,
+		},
+		{
+			name:    "regexp matching a known value",
+			matcher: mustMatcher(t, labels.MatchRegexp, "job", "api-.*"),
+			values:  []string{"api-server"},
+		},
+		{
+			name:    "regexp matching no known value",
+			matcher: mustMatcher(t, labels.MatchRegexp, "job", "web-.*"),
+			values:  []string{"api-server"},
+			wantBad: true,
+		},
+		{
+			name:    "empty-value equality matches absence of the label",
+			matcher: mustMatcher(t, labels.MatchEqual, "job", ""),
+			values:  []string{"api-server"},
+		},
+		{
+			name:    "empty regexp matches absence of the label",
+			matcher: mustMatcher(t, labels.MatchRegexp, "job", ""),
+			values:  []string{"api-server"},
+		},
+		{
+			name:    "catch-all regexp matches absence of the label",
+			matcher: mustMatcher(t, labels.MatchRegexp, "job", ".*"),
+			values:  []string{"api-server"},
+		},
+		{
+			name:    "not-equal matcher is never flagged",
+			matcher: mustMatcher(t, labels.MatchNotEqual, "job", "api-servr"),
+			values:  []string{"api-server"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := New(Config{})
+			l.labelValues["job"] = tc.values
+
+			bad, err := l.labelMatchersValid(metricSelector{
+				Name:     "up",
+				Matchers: []*labels.Matcher{tc.matcher},
+			})
+			if err != nil {
+				t.Fatalf("labelMatchersValid: %v", err)
+			}
+			if got := len(bad) > 0; got != tc.wantBad {
+				t.Errorf("labelMatchersValid(%s) bad=%v, want %v", tc.matcher, got, tc.wantBad)
+			}
+		})
+	}
+}
+
+func TestComputeRangeStats(t *testing.T) {
+	rng := v1.Range{
+		Start: time.Unix(0, 0),
+		End:   time.Unix(20, 0),
+		Step:  5 * time.Second,
+	}
+
+	t.Run("empty matrix", func(t *testing.T) {
+		stats := computeRangeStats(model.Matrix{}, rng)
+		if !stats.Empty {
+			t.Errorf("Empty = false, want true")
+		}
+	})
+
+	t.Run("series with no samples", func(t *testing.T) {
+		stats := computeRangeStats(model.Matrix{{Values: nil}}, rng)
+		if !stats.Empty {
+			t.Errorf("Empty = false, want true")
+		}
+	})
+
+	t.Run("full window, two series", func(t *testing.T) {
+		var values []model.SamplePair
+		for sec := int64(0); sec <= 20; sec += 5 {
+			values = append(values, model.SamplePair{Timestamp: model.TimeFromUnixNano(sec * int64(time.Second))})
+		}
+		matrix := model.Matrix{
+			{Values: values},
+			{Values: values},
+		}
+
+		stats := computeRangeStats(matrix, rng)
+		if stats.Empty {
+			t.Fatalf("Empty = true, want false")
+		}
+		if stats.MinSeries != 2 || stats.MaxSeries != 2 {
+			t.Errorf("MinSeries/MaxSeries = %d/%d, want 2/2", stats.MinSeries, stats.MaxSeries)
+		}
+		if stats.AvgSeries != 2 {
+			t.Errorf("AvgSeries = %v, want 2", stats.AvgSeries)
+		}
+		if stats.Gaps != 0 {
+			t.Errorf("Gaps = %d, want 0", stats.Gaps)
+		}
+	})
+
+	t.Run("missing steps count as gaps", func(t *testing.T) {
+		matrix := model.Matrix{
+			{Values: []model.SamplePair{
+				{Timestamp: model.TimeFromUnixNano(0)},
+				{Timestamp: model.TimeFromUnixNano(10 * int64(time.Second))},
+			}},
+		}
+
+		stats := computeRangeStats(matrix, rng)
+		if stats.Gaps != 3 {
+			t.Errorf("Gaps = %d, want 3", stats.Gaps)
+		}
+		if stats.AvgSeries != 1 {
+			t.Errorf("AvgSeries = %v, want 1", stats.AvgSeries)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import "fmt"
+
+// Kind distinguishes recording rules, whose left-hand side produces a
+// metric other rules may legitimately depend on, from alerting rules.
+type Kind string
+
+const (
+	KindRecording Kind = "recording"
+	KindAlerting  Kind = "alerting"
+)
+
+// Rule carries enough identity about a loaded rule to both evaluate it and
+// report useful messages about it.
+type Rule struct {
+	Name  string
+	Kind  Kind
+	Query string
+	// Record is the metric name produced by the rule. It's only set for
+	// recording rules.
+	Record string
+	// File is the rule file the rule was loaded from. It's empty for
+	// rules read from a live Prometheus.
+	File string
+	// Group is the name of the rule group the rule belongs to.
+	Group string
+}
+
+// ID returns a file/group-qualified identity for the rule, suitable for
+// inclusion in Finding messages, e.g. "file.yml:group[foo]:rule[HighErrors]".
+func (r Rule) ID() string {
+	switch {
+	case r.File != "":
+		return fmt.Sprintf("%s:group[%s]:rule[%s]", r.File, r.Group, r.Name)
+	case r.Group != "":
+		return fmt.Sprintf("group[%s]:rule[%s]", r.Group, r.Name)
+	default:
+		return r.Name
+	}
+}
+
+// RuleSource provides the rules that should be linted, regardless of
+// whether they come from a running Prometheus or from files on disk. It may
+// also report Findings of its own, e.g. a rule file that failed to parse,
+// alongside whatever rules it could still load.
+type RuleSource interface {
+	GetRules() ([]Rule, []Finding, error)
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+// apiRuleSource reads the rule groups currently loaded by a running
+// Prometheus via its /rules endpoint.
+type apiRuleSource struct {
+	client api.Client
+}
+
+// NewAPIRuleSource builds a RuleSource that reads rules from a live
+// Prometheus through client.
+func NewAPIRuleSource(client api.Client) RuleSource {
+	return &apiRuleSource{client: client}
+}
+
+func (s *apiRuleSource) GetRules() ([]Rule, []Finding, error) {
+	res, err := v1.NewAPI(s.client).Rules(context.Background())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get rules")
+	}
+
+	var rules []Rule
+	for _, group := range res.Groups {
+		for _, rule := range group.Rules {
+			switch v := rule.(type) {
+			case v1.RecordingRule:
+				rules = append(rules, Rule{Name: v.Name, Kind: KindRecording, Query: v.Query, Record: v.Name, Group: group.Name})
+			case v1.AlertingRule:
+				rules = append(rules, Rule{Name: v.Name, Kind: KindAlerting, Query: v.Query, Group: group.Name})
+			}
+		}
+	}
+
+	return rules, nil, nil
+}
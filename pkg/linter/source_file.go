@@ -0,0 +1,92 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/rulefmt"
+)
+
+// fileRuleSource reads rule groups from the YAML files matching a glob
+// pattern, using the same parser as the Prometheus rule manager. It doesn't
+// require a running Prometheus.
+type fileRuleSource struct {
+	glob string
+}
+
+// NewFileRuleSource builds a RuleSource that reads rule groups from the
+// files matched by glob, e.g. "rules/*.yml".
+func NewFileRuleSource(glob string) RuleSource {
+	return &fileRuleSource{glob: glob}
+}
+
+const checkRuleFileParse = "rule-file-parse"
+
+func (s *fileRuleSource) GetRules() ([]Rule, []Finding, error) {
+	filenames, err := filepath.Glob(s.glob)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid -rule-files pattern %q", s.glob)
+	}
+	if len(filenames) == 0 {
+		return nil, nil, errors.Errorf("no rule files match %q", s.glob)
+	}
+
+	var rules []Rule
+	var findings []Finding
+	for _, filename := range filenames {
+		rgs, errs := rulefmt.ParseFile(filename)
+		for _, e := range errs {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Kind:     checkRuleFileParse,
+				Message:  e.Error(),
+				Position: Position{File: filename},
+			})
+		}
+		if rgs == nil {
+			continue
+		}
+
+		for _, group := range rgs.Groups {
+			for _, rule := range group.Rules {
+				name := rule.Record.Value
+				kind := KindRecording
+				if name == "" {
+					name = rule.Alert.Value
+					kind = KindAlerting
+				}
+				if rule.Expr.Value == "" {
+					findings = append(findings, Finding{
+						Severity: SeverityError,
+						Kind:     checkRuleFileParse,
+						RuleID:   Rule{Name: name, File: filename, Group: group.Name}.ID(),
+						Message:  fmt.Sprintf("empty expression at line %d", rule.Expr.Line),
+						Position: Position{File: filename, Line: rule.Expr.Line},
+					})
+					continue
+				}
+				r := Rule{Name: name, Kind: kind, Query: rule.Expr.Value, File: filename, Group: group.Name}
+				if kind == KindRecording {
+					r.Record = name
+				}
+				rules = append(rules, r)
+			}
+		}
+	}
+
+	return rules, findings, nil
+}
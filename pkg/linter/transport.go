@@ -0,0 +1,147 @@
+// Copyright 2019 Simon Pasquier
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TransportConfig describes how to authenticate against a secured
+// Prometheus, matching how Thanos' sidecar and Nightingale wrap the API
+// client.
+type TransportConfig struct {
+	BasicAuthUser     string
+	BasicAuthPass     string
+	BasicAuthPassFile string
+	BearerToken       string
+	BearerTokenFile   string
+	Headers           map[string][]string
+
+	TLSCAFile             string
+	TLSCertFile           string
+	TLSKeyFile            string
+	TLSInsecureSkipVerify bool
+}
+
+// authRoundTripper injects authentication credentials and custom headers
+// into every outgoing request before delegating to next.
+type authRoundTripper struct {
+	basicAuthUser, basicAuthPass string
+	bearerToken                  string
+	headers                      http.Header
+	next                         http.RoundTripper
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, vs := range rt.headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if rt.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+rt.bearerToken)
+	}
+	if rt.basicAuthUser != "" {
+		req.SetBasicAuth(rt.basicAuthUser, rt.basicAuthPass)
+	}
+	return rt.next.RoundTrip(req)
+}
+
+// readSecret returns value if non-empty, otherwise the trimmed contents of
+// file. It's used to support both the plain and the "-file" variant of a
+// secret flag, so that secrets don't have to be left on a process's command
+// line.
+func readSecret(value, file string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	if file == "" {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %q", file)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func newTLSConfig(cfg TransportConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.TLSInsecureSkipVerify}
+
+	if cfg.TLSCAFile != "" {
+		ca, err := ioutil.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %q", cfg.TLSCAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.Errorf("failed to parse CA certificate from %q", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// NewRoundTripper builds the http.RoundTripper used by the Prometheus API
+// client, wrapping the configured TLS transport with authentication and
+// custom headers.
+func NewRoundTripper(cfg TransportConfig) (http.RoundTripper, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	basicAuthPass, err := readSecret(cfg.BasicAuthPass, cfg.BasicAuthPassFile)
+	if err != nil {
+		return nil, err
+	}
+	bearerToken, err := readSecret(cfg.BearerToken, cfg.BearerTokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr := make(http.Header)
+	for k, vs := range cfg.Headers {
+		for _, v := range vs {
+			hdr.Add(k, v)
+		}
+	}
+
+	return &authRoundTripper{
+		basicAuthUser: cfg.BasicAuthUser,
+		basicAuthPass: basicAuthPass,
+		bearerToken:   bearerToken,
+		headers:       hdr,
+		next:          transport,
+	}, nil
+}